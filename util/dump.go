@@ -0,0 +1,93 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dumpLineWidth is the number of bytes shown per line, matching the
+// conventional `hexdump -C` / `xxd` layout.
+const dumpLineWidth = 16
+
+// Dump renders b in the canonical `offset  hex bytes  |ascii|` layout, analogous to `hexdump -C`
+func Dump(b []byte) string {
+	var sb strings.Builder
+	d := &dumper{w: &sb}
+	_, _ = d.Write(b)
+	_ = d.Close()
+	return sb.String()
+}
+
+// NewDumper returns an io.WriteCloser that streams the Dump layout to w. Close must be called to flush a final, short line.
+func NewDumper(w io.Writer) io.WriteCloser {
+	return &dumper{w: w}
+}
+
+type dumper struct {
+	w      io.Writer
+	offset int
+	buf    []byte // bytes carried over until a full line is available
+	err    error
+}
+
+func (d *dumper) Write(p []byte) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	n := len(p)
+	d.buf = append(d.buf, p...)
+	for len(d.buf) >= dumpLineWidth {
+		if err := writeDumpLine(d.w, d.offset, d.buf[:dumpLineWidth]); err != nil {
+			d.err = err
+			return 0, err
+		}
+		d.buf = d.buf[dumpLineWidth:]
+		d.offset += dumpLineWidth
+	}
+	return n, nil
+}
+
+func (d *dumper) Close() error {
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.buf) == 0 {
+		return nil
+	}
+	err := writeDumpLine(d.w, d.offset, d.buf)
+	d.offset += len(d.buf)
+	d.buf = nil
+	return err
+}
+
+// writeDumpLine writes a single `offset  hex bytes  |ascii|` line for up
+// to dumpLineWidth bytes starting at offset.
+func writeDumpLine(w io.Writer, offset int, line []byte) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%08x  ", offset)
+
+	for i := 0; i < dumpLineWidth; i++ {
+		if i == 8 {
+			sb.WriteByte(' ')
+		}
+		if i < len(line) {
+			fmt.Fprintf(&sb, "%02x ", line[i])
+		} else {
+			sb.WriteString("   ")
+		}
+	}
+
+	sb.WriteString(" |")
+	for _, bb := range line {
+		if bb >= 0x20 && bb <= 0x7e {
+			sb.WriteByte(bb)
+		} else {
+			sb.WriteByte('.')
+		}
+	}
+	sb.WriteString("|\n")
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
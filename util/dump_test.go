@@ -0,0 +1,81 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{
+			name: "short line pads hex columns and substitutes non-printable ascii",
+			in:   []byte{0x00, 0x41, 0xff},
+			want: "00000000  00 41 ff                                          |.A.|\n",
+		},
+		{
+			name: "exactly one full line has a gap after the 8th byte",
+			in:   []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			want: "00000000  00 01 02 03 04 05 06 07  08 09 0a 0b 0c 0d 0e 0f  |................|\n",
+		},
+		{
+			name: "wraps to a second line at a new offset",
+			in:   []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+			want: "00000000  00 01 02 03 04 05 06 07  08 09 0a 0b 0c 0d 0e 0f  |................|\n" +
+				"00000010  10 11                                             |..|\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dump(tt.in); got != tt.want {
+				t.Errorf("Dump(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDumper(t *testing.T) {
+	in := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}
+	want := Dump(in)
+
+	var buf bytes.Buffer
+	d := NewDumper(&buf)
+	// split the write across two calls, mid-line, to exercise buffering
+	// of a partial line across Write calls.
+	if _, err := d.Write(in[:10]); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := d.Write(in[10:]); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if buf.String() != want {
+		t.Errorf("NewDumper streamed = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestItohWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		i     uint16
+		width int
+		want  string
+	}{
+		{name: "pads with leading zeros", i: 0xff, width: 4, want: "00ff"},
+		{name: "no padding needed", i: 0xabcd, width: 4, want: "abcd"},
+		{name: "width smaller than value is a no-op", i: 0xabcd, width: 2, want: "abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ItohWidth(tt.i, tt.width); got != tt.want {
+				t.Errorf("ItohWidth(%#x, %d) = %q, want %q", tt.i, tt.width, got, tt.want)
+			}
+		})
+	}
+}
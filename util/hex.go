@@ -1,31 +1,258 @@
 package util
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// hexDigits is the standard lower-case hex alphabet, used by the
+// streaming encoders below to avoid a fmt.Sprintf per byte.
+const hexDigits = "0123456789abcdef"
+
+// hexScratchSize is the size of the scratch buffer ItohNTo/BtohTo batch
+// writes into before flushing to the underlying io.Writer.
+const hexScratchSize = 4096
 
 type Intish interface {
 	int | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64
 }
 
+// intMagnitude returns the absolute value of i as a uint64, along with
+// whether i was negative, so callers can encode the sign and digits
+// separately without relying on fmt.
+func intMagnitude[I Intish](i I) (mag uint64, negative bool) {
+	if u, ok := any(i).(uint64); ok {
+		return u, false
+	}
+	v := int64(i)
+	if v < 0 {
+		return uint64(-v), true
+	}
+	return uint64(v), false
+}
+
+// appendHexDigits writes the hex digits of v into buf starting at pos
+// (no leading zeros, "0" for a zero value) and returns the new pos.
+func appendHexDigits(buf []byte, pos int, v uint64) int {
+	if v == 0 {
+		buf[pos] = '0'
+		return pos + 1
+	}
+	var tmp [16]byte
+	i := len(tmp)
+	for v > 0 {
+		i--
+		tmp[i] = hexDigits[v&0xf]
+		v >>= 4
+	}
+	return pos + copy(buf[pos:], tmp[i:])
+}
+
 // Itoh is helpful for dumping "hex" representations of ints
 func Itoh[I Intish](i I) string {
 	return fmt.Sprintf("%x", i)
 }
 
+// ItohWidth is Itoh zero-padded to width hex digits, e.g. ItohWidth(0xff, 4) == "00ff"
+func ItohWidth[I Intish](i I, width int) string {
+	return fmt.Sprintf("%0*x", width, i)
+}
+
 // ItohN is helpful for dumping "hex" representations of N count ints
 func ItohN[I Intish](iN []I) string {
-	s := ""
+	var sb strings.Builder
+	sb.Grow(2 * len(iN))
 	for _, i := range iN {
-		s += Itoh(i) + " "
+		sb.WriteString(Itoh(i))
+		sb.WriteByte(' ')
 	}
+	return sb.String()
+}
 
-	return s
+// ItohNTo streams the same output as ItohN directly to w through a
+// preallocated scratch buffer, avoiding both the intermediate string
+// ItohN builds and the per-element fmt.Sprintf that Itoh does. It
+// returns the number of bytes written.
+func ItohNTo[I Intish](w io.Writer, iN []I) (int, error) {
+	var scratch [hexScratchSize]byte
+	n := 0
+	pos := 0
+	for _, i := range iN {
+		// An element needs at most 1 sign byte + 16 hex digits + 1
+		// trailing space; flush first if that might not fit.
+		if pos+32 > len(scratch) {
+			written, err := w.Write(scratch[:pos])
+			n += written
+			if err != nil {
+				return n, err
+			}
+			pos = 0
+		}
+		mag, neg := intMagnitude(i)
+		if neg {
+			scratch[pos] = '-'
+			pos++
+		}
+		pos = appendHexDigits(scratch[:], pos, mag)
+		scratch[pos] = ' '
+		pos++
+	}
+	if pos > 0 {
+		written, err := w.Write(scratch[:pos])
+		n += written
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
 }
 
 // Btoh is helpful for dumping "hex" representations of bytes (uint8)
 func Btoh(b []byte) string {
-	s := ""
+	var sb strings.Builder
+	sb.Grow(2 * len(b))
 	for _, bb := range b {
-		s += fmt.Sprintf("%x", bb)
+		sb.WriteByte(hexDigits[bb>>4])
+		sb.WriteByte(hexDigits[bb&0x0f])
+	}
+	return sb.String()
+}
+
+// BtohTo is Btoh, streamed directly to w through a preallocated scratch buffer
+func BtohTo(w io.Writer, b []byte) (int, error) {
+	var scratch [hexScratchSize]byte
+	n := 0
+	pos := 0
+	for _, bb := range b {
+		if pos+2 > len(scratch) {
+			written, err := w.Write(scratch[:pos])
+			n += written
+			if err != nil {
+				return n, err
+			}
+			pos = 0
+		}
+		scratch[pos] = hexDigits[bb>>4]
+		scratch[pos+1] = hexDigits[bb&0x0f]
+		pos += 2
+	}
+	if pos > 0 {
+		written, err := w.Write(scratch[:pos])
+		n += written
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// trimHexPrefix strips an optional "0x"/"0X" prefix so callers can pass
+// either the bare digits Itoh/Btoh emit or a prefixed literal.
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
 	}
 	return s
 }
+
+// bitSizeOf reports the bit width of I's concrete type, so Htoi can
+// reject values that overflow it instead of silently truncating them.
+func bitSizeOf[I Intish]() int {
+	var zero I
+	switch any(zero).(type) {
+	case int8, uint8:
+		return 8
+	case int16, uint16:
+		return 16
+	case int32, uint32:
+		return 32
+	case int:
+		return strconv.IntSize
+	default: // int64, uint64
+		return 64
+	}
+}
+
+// isSigned reports whether I's concrete type is signed.
+func isSigned[I Intish]() bool {
+	var zero I
+	switch any(zero).(type) {
+	case int, int8, int16, int32, int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Htoi is the inverse of Itoh: it parses a hex string back into an
+// Intish, rejecting values that don't fit in I's width (e.g.
+// Htoi[uint8]("1ff") errors instead of silently truncating to 255, and
+// Htoi[int8]("ff") errors rather than wrapping around to -1). A leading
+// "-", as Itoh produces for negative signed values, is handled so the
+// round trip holds for signed Intish too.
+func Htoi[I Intish](s string) (I, error) {
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	}
+	s = trimHexPrefix(s)
+	bits := bitSizeOf[I]()
+	v, err := strconv.ParseUint(s, 16, bits)
+	if err != nil {
+		return 0, fmt.Errorf("util: Htoi: %w", err)
+	}
+	if !neg && isSigned[I]() {
+		max := uint64(1)<<(uint(bits)-1) - 1
+		if v > max {
+			return 0, fmt.Errorf("util: Htoi: value %#x overflows %d-bit signed range", v, bits)
+		}
+	}
+	if neg && !isSigned[I]() && v != 0 {
+		return 0, fmt.Errorf("util: Htoi: negative value -%#x for unsigned type", v)
+	}
+	if neg && isSigned[I]() && v > uint64(1)<<(uint(bits)-1) {
+		return 0, fmt.Errorf("util: Htoi: value -%#x overflows %d-bit signed range", v, bits)
+	}
+	if neg {
+		return I(-int64(v)), nil
+	}
+	return I(v), nil
+}
+
+// HtoiN is the inverse of ItohN: it parses the whitespace-separated hex
+// string ItohN emits back into a slice of Intish.
+func HtoiN[I Intish](s string) ([]I, error) {
+	fields := strings.Fields(s)
+	out := make([]I, 0, len(fields))
+	for _, f := range fields {
+		i, err := Htoi[I](f)
+		if err != nil {
+			return nil, fmt.Errorf("util: HtoiN: %w", err)
+		}
+		out = append(out, i)
+	}
+	return out, nil
+}
+
+// Htob is the inverse of Btoh: it parses a concatenated hex string back
+// into bytes. Unlike Htoi, it requires an even number of hex digits -
+// an odd-length input is ambiguous about which nibble is unpaired, so
+// it is rejected rather than silently zero-padded.
+func Htob(s string) ([]byte, error) {
+	s = trimHexPrefix(s)
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("util: Htob: odd-length hex string %q", s)
+	}
+	b := make([]byte, len(s)/2)
+	for i := range b {
+		v, err := strconv.ParseUint(s[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("util: Htob: %w", err)
+		}
+		b[i] = byte(v)
+	}
+	return b, nil
+}
@@ -0,0 +1,76 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestItohNTo(t *testing.T) {
+	in := []int32{0, 1, -1, 255, -255, 0x7fffffff}
+	want := ItohN(in)
+
+	var buf bytes.Buffer
+	n, err := ItohNTo(&buf, in)
+	if err != nil {
+		t.Fatalf("ItohNTo() error = %v", err)
+	}
+	if n != buf.Len() {
+		t.Errorf("ItohNTo() returned n = %d, want %d (buf.Len())", n, buf.Len())
+	}
+	if buf.String() != want {
+		t.Errorf("ItohNTo() = %q, want %q (from ItohN)", buf.String(), want)
+	}
+}
+
+// BenchmarkBtoh exercises the strings.Builder-based encoder against a
+// 64KB input, roughly the size of a full memory image.
+func BenchmarkBtoh(b *testing.B) {
+	buf := make([]byte, 64*1024)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Btoh(buf)
+	}
+}
+
+// BenchmarkBtohTo exercises the streaming encoder over the same input,
+// writing to io.Discard so only the encoding cost is measured.
+func BenchmarkBtohTo(b *testing.B) {
+	buf := make([]byte, 64*1024)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BtohTo(io.Discard, buf)
+	}
+}
+
+// BenchmarkItohN exercises the strings.Builder-based encoder against
+// 16384 uint32s (64KB of underlying data).
+func BenchmarkItohN(b *testing.B) {
+	in := make([]uint32, 16*1024)
+	for i := range in {
+		in[i] = uint32(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ItohN(in)
+	}
+}
+
+// BenchmarkItohNTo exercises the streaming encoder over the same input,
+// writing to io.Discard so only the encoding cost is measured.
+func BenchmarkItohNTo(b *testing.B) {
+	in := make([]uint32, 16*1024)
+	for i := range in {
+		in[i] = uint32(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ItohNTo(io.Discard, in)
+	}
+}
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestHtoi(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int32
+		wantErr bool
+	}{
+		{name: "basic", in: "ff", want: 0xff},
+		{name: "0x prefix", in: "0xff", want: 0xff},
+		{name: "0X prefix", in: "0Xff", want: 0xff},
+		{name: "zero", in: "0", want: 0},
+		{name: "negative, round-trips with Itoh", in: "-1", want: -1},
+		{name: "negative with prefix", in: "-0xff", want: -0xff},
+		{name: "invalid digit", in: "fg", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Htoi[int32](tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Htoi(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Htoi(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHtoiOverflow(t *testing.T) {
+	// 0x1ff doesn't fit in a uint8; it must error, not truncate to 0xff.
+	if got, err := Htoi[uint8]("1ff"); err == nil {
+		t.Errorf("Htoi[uint8](\"1ff\") = %v, want overflow error", got)
+	}
+	// 0xff doesn't fit in a signed int8 (max 0x7f); it must error, not
+	// wrap around to -1.
+	if got, err := Htoi[int8]("ff"); err == nil {
+		t.Errorf("Htoi[int8](\"ff\") = %v, want overflow error", got)
+	}
+}
+
+func TestHtoiItohRoundTrip(t *testing.T) {
+	for _, i := range []int32{0, 1, -1, 255, -255, 0x7fffffff, -0x7fffffff} {
+		s := Itoh(i)
+		got, err := Htoi[int32](s)
+		if err != nil {
+			t.Fatalf("Htoi(Itoh(%d)) = %q: error = %v", i, s, err)
+		}
+		if got != i {
+			t.Errorf("Htoi(Itoh(%d)) = %d, want %d", i, got, i)
+		}
+	}
+}
+
+func TestHtoiN(t *testing.T) {
+	in := []uint16{0x1, 0x22, 0x333, 0xffff}
+	s := ItohN(in)
+
+	got, err := HtoiN[uint16](s)
+	if err != nil {
+		t.Fatalf("HtoiN(%q) error = %v", s, err)
+	}
+	if len(got) != len(in) {
+		t.Fatalf("HtoiN(%q) = %v, want %v", s, got, in)
+	}
+	for i := range in {
+		if got[i] != in[i] {
+			t.Errorf("HtoiN(%q)[%d] = %v, want %v", s, i, got[i], in[i])
+		}
+	}
+}
+
+func TestHtob(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "basic", in: "00ff7f", want: []byte{0x00, 0xff, 0x7f}},
+		{name: "0x prefix", in: "0x00ff7f", want: []byte{0x00, 0xff, 0x7f}},
+		{name: "empty", in: "", want: []byte{}},
+		{name: "odd length", in: "abc", wantErr: true},
+		{name: "invalid digit", in: "zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Htob(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Htob(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Htob(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Htob(%q)[%d] = %x, want %x", tt.in, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHtobBtohRoundTrip(t *testing.T) {
+	b := []byte{0x00, 0x01, 0x7f, 0x80, 0xff, 0xde, 0xad, 0xbe, 0xef}
+
+	got, err := Htob(Btoh(b))
+	if err != nil {
+		t.Fatalf("Htob(Btoh(b)) error = %v", err)
+	}
+	if len(got) != len(b) {
+		t.Fatalf("Htob(Btoh(b)) = %v, want %v", got, b)
+	}
+	for i := range b {
+		if got[i] != b[i] {
+			t.Errorf("Htob(Btoh(b))[%d] = %x, want %x", i, got[i], b[i])
+		}
+	}
+}